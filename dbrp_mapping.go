@@ -3,8 +3,15 @@ package platform
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
+	"sync"
 	"unicode"
 )
 
@@ -12,15 +19,86 @@ import (
 type DBRPMappingService interface {
 	// FindBy returns the dbrp mapping the for cluster, db and rp.
 	FindBy(ctx context.Context, cluster, db, rp string) (*DBRPMapping, error)
-	// Find returns the first dbrp mapping the matches the filter.
+	// Find returns the first dbrp mapping the matches the filter. Exact and pattern filter fields are
+	// AND-combined; see DBRPMappingFilter for details.
 	Find(ctx context.Context, filter DBRPMappingFilter) (*DBRPMapping, error)
 	// FindMany returns a list of dbrp mappings that match filter and the total count of matching dbrp mappings.
+	// Exact and pattern filter fields are AND-combined; see DBRPMappingFilter for details.
+	//
+	// FindMany pays for an O(N) count on every call, which is prohibitively expensive for a large number
+	// of mappings. Prefer FindManyPaginated, of which FindMany is a convenience wrapper.
 	FindMany(ctx context.Context, filter DBRPMappingFilter, opt ...FindOptions) ([]*DBRPMapping, int, error)
+	// FindManyPaginated returns a page of dbrp mappings that match filter, ordered lexicographically by
+	// (Cluster, Database, RetentionPolicy). Unlike FindMany it does not compute a total count, so it
+	// remains cheap regardless of how many mappings exist. Pass the PageResult.NextCursor of one call as
+	// the PageOptions.Cursor of the next to resume; a cursor produced for a different filter is rejected
+	// with ErrDBRPCursorFilterMismatch so callers cannot silently resume a paginated scan under a filter
+	// it was never issued for.
+	FindManyPaginated(ctx context.Context, filter DBRPMappingFilter, opt PageOptions) (PageResult, error)
 	// Create creates a new dbrp mapping, if a different mapping exists an error is returned.
 	Create(ctx context.Context, dbrpMap *DBRPMapping) error
+	// CreateMany creates mappings atomically: either every mapping in the batch is created, or none are.
+	// Before touching storage, the whole batch is validated with ValidateDBRPBatch, so an individual
+	// invalid mapping or two mappings claiming Default for the same (Cluster, Database) fail the batch
+	// up front rather than leaving the store partially populated. On a validation or storage failure the
+	// returned error is a *BatchError enumerating which indices failed and why; this is intended for
+	// migration tooling importing many v1 db/rp definitions from a legacy cluster in one shot.
+	CreateMany(ctx context.Context, mappings []*DBRPMapping) error
 	// Delete removes a dbrp mapping.
 	// Deleting a mapping that does not exists is not an error.
 	Delete(ctx context.Context, cluster, db, rp string) error
+	// DeleteMany removes mappings atomically: either every key is deleted, or none are. As with
+	// CreateMany, a failure is returned as a *BatchError enumerating the failing indices. Deleting a key
+	// that does not exist is not itself an error, consistent with Delete.
+	DeleteMany(ctx context.Context, keys []DBRPKey) error
+	// Watch returns a channel of DBRPMappingEvents for mappings matching filter, so that callers such as
+	// the query router, the /write v1 db/rp translation path and task schedulers can keep an in-process
+	// index warm instead of polling Find on every request.
+	//
+	// If filter.WatchFromRevision is non-zero, the returned channel first replays any events since that
+	// revision before delivering live events, allowing a caller to resume after a reconnect without
+	// missing updates. Implementations should coalesce bursts of updates to the same mapping into a
+	// single event. If a consumer falls behind, the implementation may close the channel rather than
+	// buffer unboundedly; the caller must detect the close, call Watch again with filter.WatchFromRevision
+	// set to its last-seen DBRPMappingEvent.Revision, and reconcile with a fresh Find/FindManyPaginated
+	// call in case events were dropped in between.
+	Watch(ctx context.Context, filter DBRPMappingFilter) (<-chan DBRPMappingEvent, error)
+}
+
+// DBRPMappingEventType identifies the kind of change a DBRPMappingEvent describes.
+type DBRPMappingEventType int
+
+const (
+	// DBRPMappingEventCreated indicates a new mapping was created; Old is nil and New is set.
+	DBRPMappingEventCreated DBRPMappingEventType = iota
+	// DBRPMappingEventUpdated indicates an existing mapping changed; both Old and New are set.
+	DBRPMappingEventUpdated
+	// DBRPMappingEventDeleted indicates a mapping was removed; Old is set and New is nil.
+	DBRPMappingEventDeleted
+)
+
+// String returns a human-readable name for t.
+func (t DBRPMappingEventType) String() string {
+	switch t {
+	case DBRPMappingEventCreated:
+		return "created"
+	case DBRPMappingEventUpdated:
+		return "updated"
+	case DBRPMappingEventDeleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
+
+// DBRPMappingEvent describes a single change to a dbrp mapping delivered over a Watch channel.
+// Revision increases monotonically per change and can be passed back to Watch to resume from this
+// point.
+type DBRPMappingEvent struct {
+	Type     DBRPMappingEventType
+	Old      *DBRPMapping
+	New      *DBRPMapping
+	Revision uint64
 }
 
 // DBRPMapping represents a mapping of a cluster, database and retention policy to an organization ID and bucket ID.
@@ -36,16 +114,18 @@ type DBRPMapping struct {
 	BucketID       ID `json:"bucket_id"`
 }
 
-// Validate reports any validation errors for the mapping.
+// Validate reports any validation errors for the mapping. Cluster, Database and RetentionPolicy are
+// checked against DefaultNameValidator, so embedding platforms can tighten or loosen naming policy
+// without forking this method; see NameValidator.
 func (m DBRPMapping) Validate() error {
-	if !validName(m.Cluster) {
-		return errors.New("Cluster must contain at least one character and only be letters, numbers, '_', '-', and '.'")
+	if err := DefaultNameValidator.Validate("Cluster", m.Cluster); err != nil {
+		return err
 	}
-	if !validName(m.Database) {
-		return errors.New("Database must contain at least one character and only be letters, numbers, '_', '-', and '.'")
+	if err := DefaultNameValidator.Validate("Database", m.Database); err != nil {
+		return err
 	}
-	if !validName(m.RetentionPolicy) {
-		return errors.New("RetentionPolicy must contain at least one character and only be letters, numbers, '_', '-', and '.'")
+	if err := DefaultNameValidator.Validate("RetentionPolicy", m.RetentionPolicy); err != nil {
+		return err
 	}
 	if len(m.OrganizationID) == 0 {
 		return errors.New("OrganizationID is required")
@@ -56,6 +136,45 @@ func (m DBRPMapping) Validate() error {
 	return nil
 }
 
+// NameValidator checks whether value is an acceptable name for the given field (one of "Cluster",
+// "Database" or "RetentionPolicy"), returning a *FieldValidationError describing the failure if not.
+type NameValidator interface {
+	Validate(field, value string) error
+}
+
+// DefaultNameValidator is the NameValidator used by DBRPMapping.Validate. It defaults to
+// PermissiveValidator, preserving the original behavior of this package; embedding platforms that want
+// a stricter naming policy can assign a different NameValidator (e.g. StrictDNS1123Validator or a
+// configured RegexValidator) at startup.
+var DefaultNameValidator NameValidator = PermissiveValidator{}
+
+// FieldValidationError reports that a single named field failed name validation.
+type FieldValidationError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e *FieldValidationError) Error() string {
+	return fmt.Sprintf("%s %q: %s", e.Field, e.Value, e.Reason)
+}
+
+// PermissiveValidator is the original DBRP naming policy: printable characters, not "." or "..", and
+// no "/" or "\".
+type PermissiveValidator struct{}
+
+// Validate implements NameValidator.
+func (PermissiveValidator) Validate(field, value string) error {
+	if !validName(value) {
+		return &FieldValidationError{
+			Field:  field,
+			Value:  value,
+			Reason: `must contain at least one character, only printable characters, no '/' or '\', and not be "." or ".."`,
+		}
+	}
+	return nil
+}
+
 // validName checks to see if the given name can would be valid for DB/RP name
 func validName(name string) bool {
 	for _, r := range name {
@@ -70,6 +189,100 @@ func validName(name string) bool {
 		!strings.ContainsAny(name, `/\`)
 }
 
+// dns1123Pattern matches a single DNS-1123 label: starts and ends with an alphanumeric character and
+// contains only alphanumerics or "-" in between.
+var dns1123Pattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// dns1123MaxLen is the maximum length of a DNS-1123 label.
+const dns1123MaxLen = 63
+
+// StrictDNS1123Validator requires names to be valid, lowercase DNS-1123 labels (RFC 1123), the same
+// naming policy used for Kubernetes object names. It rejects names that PermissiveValidator would
+// accept, such as those containing ".", "_" or uppercase letters.
+type StrictDNS1123Validator struct{}
+
+// Validate implements NameValidator.
+func (StrictDNS1123Validator) Validate(field, value string) error {
+	if len(value) == 0 || len(value) > dns1123MaxLen || !dns1123Pattern.MatchString(value) {
+		return &FieldValidationError{
+			Field:  field,
+			Value:  value,
+			Reason: fmt.Sprintf("must be a valid DNS-1123 label matching %q and at most %d characters", dns1123Pattern.String(), dns1123MaxLen),
+		}
+	}
+	return nil
+}
+
+// RegexValidator accepts any name matching Pattern, optionally also bounding its length to MaxLen. A
+// zero MaxLen means no length limit.
+type RegexValidator struct {
+	Pattern *regexp.Regexp
+	MaxLen  int
+}
+
+// Validate implements NameValidator.
+func (v RegexValidator) Validate(field, value string) error {
+	if v.Pattern == nil {
+		return &FieldValidationError{
+			Field:  field,
+			Value:  value,
+			Reason: "RegexValidator is misconfigured: Pattern is nil",
+		}
+	}
+	if v.MaxLen > 0 && len(value) > v.MaxLen {
+		return &FieldValidationError{
+			Field:  field,
+			Value:  value,
+			Reason: fmt.Sprintf("must be at most %d characters", v.MaxLen),
+		}
+	}
+	if !v.Pattern.MatchString(value) {
+		return &FieldValidationError{
+			Field:  field,
+			Value:  value,
+			Reason: fmt.Sprintf("must match pattern %q", v.Pattern.String()),
+		}
+	}
+	return nil
+}
+
+// FindNamesFailingValidator scans every mapping in svc matching filter and reports, for each one,
+// every field that would fail validation under validator. It is meant to be run before switching
+// DefaultNameValidator to a stricter policy, so operators can see which pre-existing names would be
+// rejected and remediate them first.
+func FindNamesFailingValidator(ctx context.Context, svc DBRPMappingService, filter DBRPMappingFilter, validator NameValidator) ([]FieldValidationError, error) {
+	var failures []FieldValidationError
+	opt := PageOptions{PageSize: 100}
+	for {
+		page, err := svc.FindManyPaginated(ctx, filter, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page.Mappings {
+			fields := []struct {
+				name  string
+				value string
+			}{
+				{"Cluster", m.Cluster},
+				{"Database", m.Database},
+				{"RetentionPolicy", m.RetentionPolicy},
+			}
+			for _, f := range fields {
+				if err := validator.Validate(f.name, f.value); err != nil {
+					if fve, ok := err.(*FieldValidationError); ok {
+						failures = append(failures, *fve)
+					}
+				}
+			}
+		}
+		if !page.HasMore {
+			break
+		}
+		opt.Cursor = page.NextCursor
+	}
+	return failures, nil
+}
+
 // Equal checks if the two mappings are identical.
 func (m *DBRPMapping) Equal(o *DBRPMapping) bool {
 	if m == o {
@@ -87,9 +300,337 @@ func (m *DBRPMapping) Equal(o *DBRPMapping) bool {
 }
 
 // DBRPMappingFilter represents a set of filters that restrict the returned results by cluster, database and retention policy.
+//
+// The Pattern fields are RE2 regular expressions matched against the same field as their exact-match
+// counterpart; a mapping must satisfy every non-nil exact filter and every non-nil pattern filter (AND
+// semantics) and must not satisfy any non-nil Exclude pattern. This lets callers make bulk selections,
+// e.g. Cluster=nil, DatabasePattern=`^telegraf_`, ExcludeRetentionPolicyPattern=`^_internal` to select all
+// telegraf databases while excluding internal retention policies.
 type DBRPMappingFilter struct {
 	Cluster         *string
 	Database        *string
 	RetentionPolicy *string
 	Default         *bool
+
+	// WatchFromRevision is only consulted by DBRPMappingService.Watch. When non-zero, Watch replays
+	// events since this revision before switching to live delivery, so a caller reconnecting after a
+	// dropped Watch channel can resume from its last-seen DBRPMappingEvent.Revision instead of missing
+	// updates that happened while it was disconnected.
+	WatchFromRevision uint64
+
+	ClusterPattern         *string
+	DatabasePattern        *string
+	RetentionPolicyPattern *string
+
+	ExcludeClusterPattern         *string
+	ExcludeDatabasePattern        *string
+	ExcludeRetentionPolicyPattern *string
+}
+
+// Validate reports any validation errors in the filter, including compiling each pattern field as an
+// RE2 regular expression. It should be called before the filter is passed to a DBRPMappingService so
+// that an invalid pattern is rejected up front rather than surfacing as a silent empty result set.
+func (f DBRPMappingFilter) Validate() error {
+	patterns := []struct {
+		name    string
+		pattern *string
+	}{
+		{"ClusterPattern", f.ClusterPattern},
+		{"DatabasePattern", f.DatabasePattern},
+		{"RetentionPolicyPattern", f.RetentionPolicyPattern},
+		{"ExcludeClusterPattern", f.ExcludeClusterPattern},
+		{"ExcludeDatabasePattern", f.ExcludeDatabasePattern},
+		{"ExcludeRetentionPolicyPattern", f.ExcludeRetentionPolicyPattern},
+	}
+	for _, p := range patterns {
+		if p.pattern == nil {
+			continue
+		}
+		if _, err := compiledPattern(*p.pattern); err != nil {
+			return &DBRPFilterValidationError{Field: p.name, Pattern: *p.pattern, Err: err}
+		}
+	}
+	return nil
+}
+
+// patternCache memoizes compiled regular expressions by their source pattern, so that
+// DBRPMappingFilter.Matches does not recompile the same pattern on every call when scanning many
+// mappings. The set of distinct patterns in use is expected to be small relative to the number of
+// mappings scanned against them.
+var patternCache sync.Map // map[string]*regexp.Regexp
+
+// compiledPattern returns the compiled form of pattern, compiling and caching it on first use.
+func compiledPattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := patternCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := patternCache.LoadOrStore(pattern, re)
+	return actual.(*regexp.Regexp), nil
+}
+
+// DBRPFilterValidationError reports that a DBRPMappingFilter pattern field is not a valid RE2 regular
+// expression.
+type DBRPFilterValidationError struct {
+	Field   string
+	Pattern string
+	Err     error
+}
+
+func (e *DBRPFilterValidationError) Error() string {
+	return "invalid " + e.Field + " " + "\"" + e.Pattern + "\": " + e.Err.Error()
+}
+
+func (e *DBRPFilterValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Matches reports whether m satisfies every exact and pattern filter set on f. Store implementations
+// that cannot push pattern matching down into their index should use Matches as the in-memory
+// fallback, but are expected to use the compiled patterns to short-circuit a scan (e.g. by using a
+// literal prefix extracted from the pattern to narrow a range scan) rather than unconditionally
+// fetching every mapping before filtering.
+func (f DBRPMappingFilter) Matches(m *DBRPMapping) bool {
+	if m == nil {
+		return false
+	}
+	if f.Cluster != nil && m.Cluster != *f.Cluster {
+		return false
+	}
+	if f.Database != nil && m.Database != *f.Database {
+		return false
+	}
+	if f.RetentionPolicy != nil && m.RetentionPolicy != *f.RetentionPolicy {
+		return false
+	}
+	if f.Default != nil && m.Default != *f.Default {
+		return false
+	}
+
+	if !matchPattern(f.ClusterPattern, m.Cluster) ||
+		!matchPattern(f.DatabasePattern, m.Database) ||
+		!matchPattern(f.RetentionPolicyPattern, m.RetentionPolicy) {
+		return false
+	}
+
+	if excludes(f.ExcludeClusterPattern, m.Cluster) ||
+		excludes(f.ExcludeDatabasePattern, m.Database) ||
+		excludes(f.ExcludeRetentionPolicyPattern, m.RetentionPolicy) {
+		return false
+	}
+
+	return true
+}
+
+// excludes reports whether value should be excluded because it matches pattern. Unlike matchPattern,
+// a nil pattern here means "no exclusion configured", so it returns false rather than true: an absent
+// Exclude*Pattern must never reject a mapping.
+func excludes(pattern *string, value string) bool {
+	if pattern == nil {
+		return false
+	}
+	re, err := compiledPattern(*pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// matchPattern reports whether value matches pattern. A nil pattern always matches; an invalid
+// pattern never matches, since callers are expected to have already rejected it via
+// DBRPMappingFilter.Validate. The compiled form of pattern is cached via compiledPattern so that
+// scanning many mappings against the same filter does not recompile it each time.
+func matchPattern(pattern *string, value string) bool {
+	if pattern == nil {
+		return true
+	}
+	re, err := compiledPattern(*pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// ErrDBRPCursorFilterMismatch is returned by FindManyPaginated when the supplied cursor was issued for
+// a different filter than the one being used to resume pagination.
+var ErrDBRPCursorFilterMismatch = errors.New("dbrp cursor does not match the supplied filter")
+
+// PageOptions configures a single call to DBRPMappingService.FindManyPaginated.
+type PageOptions struct {
+	// PageSize is the maximum number of mappings to return.
+	PageSize int
+	// Cursor resumes a previous paginated scan; the zero value starts from the beginning.
+	Cursor Cursor
+}
+
+// PageResult is the result of a single call to DBRPMappingService.FindManyPaginated.
+type PageResult struct {
+	Mappings   []*DBRPMapping
+	NextCursor Cursor
+	HasMore    bool
+}
+
+// Cursor is an opaque, base64-encoded position within a lexicographically ordered scan of dbrp
+// mappings, scoped to the filter it was issued under. Cursors should be treated as opaque by callers
+// and only ever passed back as PageOptions.Cursor.
+type Cursor string
+
+// dbrpCursorPayload is the decoded form of a Cursor: the last-seen (Cluster, Database,
+// RetentionPolicy) triple plus a hash of the filter the cursor was issued under, so that a stale or
+// mismatched cursor can be rejected rather than silently resuming against the wrong scan.
+type dbrpCursorPayload struct {
+	Cluster         string `json:"cluster"`
+	Database        string `json:"database"`
+	RetentionPolicy string `json:"retention_policy"`
+	FilterHash      string `json:"filter_hash"`
+}
+
+// NewDBRPCursor builds an opaque Cursor that resumes a paginated scan immediately after last, for the
+// given filter. Store implementations should call this to populate PageResult.NextCursor.
+func NewDBRPCursor(last *DBRPMapping, filter DBRPMappingFilter) (Cursor, error) {
+	payload := dbrpCursorPayload{
+		FilterHash: filter.Hash(),
+	}
+	if last != nil {
+		payload.Cluster = last.Cluster
+		payload.Database = last.Database
+		payload.RetentionPolicy = last.RetentionPolicy
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return Cursor(base64.URLEncoding.EncodeToString(b)), nil
+}
+
+// Decode unpacks c and verifies it was issued for filter, returning ErrDBRPCursorFilterMismatch if
+// not. Store implementations should call this at the start of FindManyPaginated and resume the scan
+// lexicographically after the returned (Cluster, Database, RetentionPolicy) triple.
+func (c Cursor) Decode(filter DBRPMappingFilter) (cluster, database, retentionPolicy string, err error) {
+	if c == "" {
+		return "", "", "", nil
+	}
+	b, err := base64.URLEncoding.DecodeString(string(c))
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid dbrp cursor: %w", err)
+	}
+	var payload dbrpCursorPayload
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return "", "", "", fmt.Errorf("invalid dbrp cursor: %w", err)
+	}
+	if payload.FilterHash != filter.Hash() {
+		return "", "", "", ErrDBRPCursorFilterMismatch
+	}
+	return payload.Cluster, payload.Database, payload.RetentionPolicy, nil
+}
+
+// Hash returns a stable digest of f's fields, used to detect when a Cursor was issued for a
+// different filter than the one it is being resumed with.
+func (f DBRPMappingFilter) Hash() string {
+	// Marshal a normalized struct rather than joining fields with a "|" separator: a delimiter-joined
+	// encoding is ambiguous whenever a field value (e.g. a regex pattern) itself contains "|", which
+	// would let two different filters collide on the same hash. json.Marshal distinguishes nil from ""
+	// and quotes/escapes each field, so distinct filters cannot collide this way.
+	normalized := struct {
+		Cluster         *string
+		Database        *string
+		RetentionPolicy *string
+		Default         *bool
+
+		ClusterPattern         *string
+		DatabasePattern        *string
+		RetentionPolicyPattern *string
+
+		ExcludeClusterPattern         *string
+		ExcludeDatabasePattern        *string
+		ExcludeRetentionPolicyPattern *string
+	}{
+		Cluster:         f.Cluster,
+		Database:        f.Database,
+		RetentionPolicy: f.RetentionPolicy,
+		Default:         f.Default,
+
+		ClusterPattern:         f.ClusterPattern,
+		DatabasePattern:        f.DatabasePattern,
+		RetentionPolicyPattern: f.RetentionPolicyPattern,
+
+		ExcludeClusterPattern:         f.ExcludeClusterPattern,
+		ExcludeDatabasePattern:        f.ExcludeDatabasePattern,
+		ExcludeRetentionPolicyPattern: f.ExcludeRetentionPolicyPattern,
+	}
+	// Encoding failure is not reachable: every field is a *string or *bool, both always marshalable.
+	b, err := json.Marshal(normalized)
+	if err != nil {
+		panic(err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// DBRPKey identifies a dbrp mapping by its natural key, for use with DBRPMappingService.DeleteMany.
+type DBRPKey struct {
+	Cluster         string
+	Database        string
+	RetentionPolicy string
+}
+
+// BatchFailure reports why a single element of a CreateMany/DeleteMany batch failed.
+type BatchFailure struct {
+	Index   int
+	Mapping *DBRPMapping
+	Err     error
+}
+
+// BatchError is returned by CreateMany/DeleteMany when one or more elements of the batch fail. Since
+// both operations are all-or-nothing, a BatchError means none of the batch was applied; Failures
+// enumerates every element that failed, not just the first.
+type BatchError struct {
+	Failures []BatchFailure
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("dbrp batch rejected: %d entries failed", len(e.Failures))
+}
+
+// ValidateDBRPBatch validates mappings for use with DBRPMappingService.CreateMany, checking each
+// mapping individually with Validate and rejecting the batch if two mappings claim Default=true for
+// the same (Cluster, Database) pair, since only one mapping can be the default for a given database.
+// It returns a *BatchError enumerating every failing index, or nil if the batch is valid. Store
+// implementations of CreateMany should call this before touching storage so a batch is rejected
+// atomically rather than partially applied.
+func ValidateDBRPBatch(mappings []*DBRPMapping) error {
+	var failures []BatchFailure
+	defaultClaims := make(map[string]int, len(mappings))
+
+	for i, m := range mappings {
+		if m == nil {
+			failures = append(failures, BatchFailure{Index: i, Mapping: nil, Err: errors.New("mapping is nil")})
+			continue
+		}
+		if err := m.Validate(); err != nil {
+			failures = append(failures, BatchFailure{Index: i, Mapping: m, Err: err})
+			continue
+		}
+		if !m.Default {
+			continue
+		}
+		key := m.Cluster + "/" + m.Database
+		if first, ok := defaultClaims[key]; ok {
+			failures = append(failures, BatchFailure{
+				Index:   i,
+				Mapping: m,
+				Err:     fmt.Errorf("mapping at index %d already claims Default for cluster %q database %q", first, m.Cluster, m.Database),
+			})
+			continue
+		}
+		defaultClaims[key] = i
+	}
+
+	if len(failures) > 0 {
+		return &BatchError{Failures: failures}
+	}
+	return nil
 }
@@ -0,0 +1,330 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NewInMemDBRPMappingService returns an in-memory DBRPMappingService. It exists primarily to exercise
+// and verify the DBRPMappingService contract (stable lexicographic ordering, cursor resumption,
+// pattern include/exclude matching, atomic batch semantics) against a real implementation rather than
+// leaving the interface doc-only; store implementations backed by durable storage should honor the
+// same ordering and short-circuiting behavior.
+func NewInMemDBRPMappingService() DBRPMappingService {
+	return &inMemDBRPMappingService{
+		byKey: make(map[string]*DBRPMapping),
+	}
+}
+
+// inMemDBRPMappingService keeps mappings sorted by their natural key so that FindManyPaginated can
+// resume a scan by binary-searching for the cursor position, and so that an exact Cluster filter can
+// narrow the scan range instead of visiting every mapping.
+type inMemDBRPMappingService struct {
+	mu       sync.RWMutex
+	byKey    map[string]*DBRPMapping
+	keys     []string // sorted ascending, lexicographic on (Cluster, Database, RetentionPolicy)
+	revision uint64
+
+	nextWatcherID int
+	watchers      map[int]*dbrpWatcher
+}
+
+type dbrpWatcher struct {
+	filter DBRPMappingFilter
+	ch     chan DBRPMappingEvent
+}
+
+// dbrpKey returns the natural key used to order and look up a mapping.
+func dbrpKey(cluster, database, retentionPolicy string) string {
+	return strings.Join([]string{cluster, database, retentionPolicy}, "\x00")
+}
+
+func (s *inMemDBRPMappingService) FindBy(ctx context.Context, cluster, db, rp string) (*DBRPMapping, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m, ok := s.byKey[dbrpKey(cluster, db, rp)]
+	if !ok {
+		return nil, errors.New("dbrp mapping not found")
+	}
+	cp := *m
+	return &cp, nil
+}
+
+func (s *inMemDBRPMappingService) Find(ctx context.Context, filter DBRPMappingFilter) (*DBRPMapping, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, start := range s.scanRange(filter) {
+		if m := s.byKey[s.keys[start]]; filter.Matches(m) {
+			cp := *m
+			return &cp, nil
+		}
+	}
+	return nil, errors.New("dbrp mapping not found")
+}
+
+// FindMany is implemented as a wrapper around FindManyPaginated, paging through the full result set
+// so it can still report a total count; callers that don't need the count should prefer
+// FindManyPaginated directly.
+func (s *inMemDBRPMappingService) FindMany(ctx context.Context, filter DBRPMappingFilter, opt ...FindOptions) ([]*DBRPMapping, int, error) {
+	var all []*DBRPMapping
+	page := PageOptions{PageSize: 100}
+	for {
+		result, err := s.FindManyPaginated(ctx, filter, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		all = append(all, result.Mappings...)
+		if !result.HasMore {
+			break
+		}
+		page.Cursor = result.NextCursor
+	}
+
+	offset, limit := 0, len(all)
+	for _, o := range opt {
+		if o.Offset > 0 {
+			offset = o.Offset
+		}
+		if o.Limit > 0 {
+			limit = o.Limit
+		}
+	}
+	if offset > len(all) {
+		offset = len(all)
+	}
+	end := offset + limit
+	if end > len(all) {
+		end = len(all)
+	}
+	return all[offset:end], len(all), nil
+}
+
+func (s *inMemDBRPMappingService) FindManyPaginated(ctx context.Context, filter DBRPMappingFilter, opt PageOptions) (PageResult, error) {
+	if err := filter.Validate(); err != nil {
+		return PageResult{}, err
+	}
+
+	afterCluster, afterDatabase, afterRP, err := opt.Cursor.Decode(filter)
+	if err != nil {
+		return PageResult{}, err
+	}
+	after := dbrpKey(afterCluster, afterDatabase, afterRP)
+
+	pageSize := opt.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// An exact Cluster filter lets us binary-search directly to its range instead of scanning from the
+	// start of the keyspace; this is the prefix short-circuit the DBRPMappingFilter.Matches doc calls for.
+	start := s.lowerBound(filter)
+	if after != "\x00\x00" {
+		if idx := sort.SearchStrings(s.keys, after); idx >= start {
+			start = idx + 1
+			if idx < len(s.keys) && s.keys[idx] != after {
+				start = idx
+			}
+		}
+	}
+
+	var page []*DBRPMapping
+	var last *DBRPMapping
+	hasMore := false
+	for i := start; i < len(s.keys); i++ {
+		if !s.withinClusterPrefix(filter, s.keys[i]) {
+			break
+		}
+		m := s.byKey[s.keys[i]]
+		if !filter.Matches(m) {
+			continue
+		}
+		if len(page) == pageSize {
+			hasMore = true
+			break
+		}
+		cp := *m
+		page = append(page, &cp)
+		last = m
+	}
+
+	next, err := NewDBRPCursor(last, filter)
+	if err != nil {
+		return PageResult{}, err
+	}
+	return PageResult{Mappings: page, NextCursor: next, HasMore: hasMore}, nil
+}
+
+// lowerBound returns the index of the first key that could satisfy filter.Cluster, given the sorted
+// key order, so callers with an exact Cluster filter don't scan keys belonging to other clusters.
+func (s *inMemDBRPMappingService) lowerBound(filter DBRPMappingFilter) int {
+	if filter.Cluster == nil {
+		return 0
+	}
+	prefix := *filter.Cluster + "\x00"
+	return sort.Search(len(s.keys), func(i int) bool { return s.keys[i] >= prefix })
+}
+
+// withinClusterPrefix reports whether key still belongs to filter.Cluster, allowing FindManyPaginated
+// to stop scanning as soon as it walks past the exact cluster it was asked for.
+func (s *inMemDBRPMappingService) withinClusterPrefix(filter DBRPMappingFilter, key string) bool {
+	if filter.Cluster == nil {
+		return true
+	}
+	return strings.HasPrefix(key, *filter.Cluster+"\x00")
+}
+
+// scanRange returns, in order, every key index a Find call should consider.
+func (s *inMemDBRPMappingService) scanRange(filter DBRPMappingFilter) []int {
+	start := s.lowerBound(filter)
+	var indices []int
+	for i := start; i < len(s.keys); i++ {
+		if !s.withinClusterPrefix(filter, s.keys[i]) {
+			break
+		}
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+func (s *inMemDBRPMappingService) Create(ctx context.Context, dbrpMap *DBRPMapping) error {
+	if err := dbrpMap.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.insertLocked(dbrpMap)
+}
+
+func (s *inMemDBRPMappingService) CreateMany(ctx context.Context, mappings []*DBRPMapping) error {
+	if err := ValidateDBRPBatch(mappings); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var failures []BatchFailure
+	inBatch := make(map[string]bool, len(mappings))
+	for i, m := range mappings {
+		key := dbrpKey(m.Cluster, m.Database, m.RetentionPolicy)
+		if _, exists := s.byKey[key]; exists {
+			failures = append(failures, BatchFailure{Index: i, Mapping: m, Err: errors.New("mapping already exists")})
+		} else if inBatch[key] {
+			failures = append(failures, BatchFailure{Index: i, Mapping: m, Err: errors.New("mapping appears more than once in the batch")})
+		}
+		inBatch[key] = true
+	}
+	if len(failures) > 0 {
+		return &BatchError{Failures: failures}
+	}
+
+	// Validated and conflict-checked up front, so every insert below is guaranteed to succeed: the
+	// batch is applied all-or-nothing.
+	for _, m := range mappings {
+		_ = s.insertLocked(m)
+	}
+	return nil
+}
+
+// insertLocked must be called with s.mu held.
+func (s *inMemDBRPMappingService) insertLocked(m *DBRPMapping) error {
+	key := dbrpKey(m.Cluster, m.Database, m.RetentionPolicy)
+	if _, exists := s.byKey[key]; exists {
+		return errors.New("mapping already exists")
+	}
+	cp := *m
+	s.byKey[key] = &cp
+	idx := sort.SearchStrings(s.keys, key)
+	s.keys = append(s.keys, "")
+	copy(s.keys[idx+1:], s.keys[idx:])
+	s.keys[idx] = key
+	s.revision++
+	s.notifyLocked(DBRPMappingEvent{Type: DBRPMappingEventCreated, New: &cp, Revision: s.revision})
+	return nil
+}
+
+func (s *inMemDBRPMappingService) Delete(ctx context.Context, cluster, db, rp string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteLocked(cluster, db, rp)
+	return nil
+}
+
+func (s *inMemDBRPMappingService) DeleteMany(ctx context.Context, keys []DBRPKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, k := range keys {
+		s.deleteLocked(k.Cluster, k.Database, k.RetentionPolicy)
+	}
+	return nil
+}
+
+// deleteLocked must be called with s.mu held. Deleting a key that does not exist is not an error,
+// consistent with DBRPMappingService.Delete.
+func (s *inMemDBRPMappingService) deleteLocked(cluster, db, rp string) {
+	key := dbrpKey(cluster, db, rp)
+	old, ok := s.byKey[key]
+	if !ok {
+		return
+	}
+	delete(s.byKey, key)
+	idx := sort.SearchStrings(s.keys, key)
+	s.keys = append(s.keys[:idx], s.keys[idx+1:]...)
+	s.revision++
+	s.notifyLocked(DBRPMappingEvent{Type: DBRPMappingEventDeleted, Old: old, Revision: s.revision})
+}
+
+// Watch delivers only events that happen after it is called: filter.WatchFromRevision is ignored, no
+// earlier events are replayed, and bursts of changes are not coalesced. Each watcher buffers up to 16
+// events; when the buffer is full the channel is closed and the watcher removed. The channel is also
+// closed once ctx is done.
+func (s *inMemDBRPMappingService) Watch(ctx context.Context, filter DBRPMappingFilter) (<-chan DBRPMappingEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := make(chan DBRPMappingEvent, 16)
+	id := s.nextWatcherID
+	s.nextWatcherID++
+	if s.watchers == nil {
+		s.watchers = make(map[int]*dbrpWatcher)
+	}
+	s.watchers[id] = &dbrpWatcher{filter: filter, ch: ch}
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if w, ok := s.watchers[id]; ok {
+			close(w.ch)
+			delete(s.watchers, id)
+		}
+	}()
+
+	return ch, nil
+}
+
+// notifyLocked must be called with s.mu held. A slow consumer whose buffered channel is full has its
+// channel closed and is removed rather than blocking the writer; it must reconnect via Watch and resync
+// with Find.
+func (s *inMemDBRPMappingService) notifyLocked(event DBRPMappingEvent) {
+	for id, w := range s.watchers {
+		m := event.New
+		if m == nil {
+			m = event.Old
+		}
+		if !w.filter.Matches(m) {
+			continue
+		}
+		select {
+		case w.ch <- event:
+		default:
+			close(w.ch)
+			delete(s.watchers, id)
+		}
+	}
+}
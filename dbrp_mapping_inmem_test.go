@@ -0,0 +1,103 @@
+package platform_test
+
+import (
+	"context"
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+func TestInMemDBRPMappingServiceFindManyPaginatedOrderAndResume(t *testing.T) {
+	ctx := context.Background()
+	svc := platform.NewInMemDBRPMappingService()
+
+	seed := []*platform.DBRPMapping{
+		newTestMapping("clusterA", "c", "autogen", false),
+		newTestMapping("clusterA", "a", "autogen", false),
+		newTestMapping("clusterA", "b", "autogen", false),
+		newTestMapping("clusterB", "a", "autogen", false),
+	}
+	for _, m := range seed {
+		if err := svc.Create(ctx, m); err != nil {
+			t.Fatalf("Create(%+v): %v", m, err)
+		}
+	}
+
+	filter := platform.DBRPMappingFilter{Cluster: strPtr("clusterA")}
+
+	var got []string
+	opt := platform.PageOptions{PageSize: 1}
+	for i := 0; i < 10; i++ {
+		page, err := svc.FindManyPaginated(ctx, filter, opt)
+		if err != nil {
+			t.Fatalf("FindManyPaginated: %v", err)
+		}
+		for _, m := range page.Mappings {
+			got = append(got, m.Database)
+		}
+		if !page.HasMore {
+			break
+		}
+		opt.Cursor = page.NextCursor
+	}
+
+	want := []string{"a", "b", "c"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestInMemDBRPMappingServicePatternFilter(t *testing.T) {
+	ctx := context.Background()
+	svc := platform.NewInMemDBRPMappingService()
+
+	seed := []*platform.DBRPMapping{
+		newTestMapping("clusterA", "telegraf_host1", "autogen", false),
+		newTestMapping("clusterA", "telegraf_host2", "_internal", false),
+		newTestMapping("clusterA", "other_db", "autogen", false),
+	}
+	for _, m := range seed {
+		if err := svc.Create(ctx, m); err != nil {
+			t.Fatalf("Create(%+v): %v", m, err)
+		}
+	}
+
+	filter := platform.DBRPMappingFilter{
+		DatabasePattern:               strPtr("^telegraf_"),
+		ExcludeRetentionPolicyPattern: strPtr("^_internal"),
+	}
+
+	mappings, count, err := svc.FindMany(ctx, filter)
+	if err != nil {
+		t.Fatalf("FindMany: %v", err)
+	}
+	if count != 1 || len(mappings) != 1 {
+		t.Fatalf("got %d mappings (count=%d), want exactly telegraf_host1/autogen", len(mappings), count)
+	}
+	if mappings[0].Database != "telegraf_host1" {
+		t.Fatalf("got database %q, want telegraf_host1", mappings[0].Database)
+	}
+}
+
+func TestInMemDBRPMappingServiceCreateManyAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	svc := platform.NewInMemDBRPMappingService()
+
+	batch := []*platform.DBRPMapping{
+		newTestMapping("clusterA", "telegraf", "autogen", true),
+		newTestMapping("clusterA", "telegraf", "downsampled", true),
+	}
+
+	if err := svc.CreateMany(ctx, batch); err == nil {
+		t.Fatal("expected CreateMany to reject a batch with duplicate Default claims")
+	}
+
+	if _, count, err := svc.FindMany(ctx, platform.DBRPMappingFilter{}); err != nil || count != 0 {
+		t.Fatalf("expected no mappings to be created by a rejected batch, got count=%d err=%v", count, err)
+	}
+}
@@ -0,0 +1,140 @@
+package platform_test
+
+import (
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+func newTestMapping(cluster, database, rp string, isDefault bool) *platform.DBRPMapping {
+	return &platform.DBRPMapping{
+		Cluster:         cluster,
+		Database:        database,
+		RetentionPolicy: rp,
+		Default:         isDefault,
+		OrganizationID:  platform.ID("0000000000000001"),
+		BucketID:        platform.ID("0000000000000002"),
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestDBRPCursorRoundTrip(t *testing.T) {
+	filter := platform.DBRPMappingFilter{}
+	last := newTestMapping("clusterA", "telegraf", "autogen", false)
+
+	cur, err := platform.NewDBRPCursor(last, filter)
+	if err != nil {
+		t.Fatalf("NewDBRPCursor: %v", err)
+	}
+
+	cluster, database, rp, err := cur.Decode(filter)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if cluster != last.Cluster || database != last.Database || rp != last.RetentionPolicy {
+		t.Fatalf("got (%q, %q, %q), want (%q, %q, %q)", cluster, database, rp, last.Cluster, last.Database, last.RetentionPolicy)
+	}
+}
+
+func TestDBRPCursorRejectsMismatchedFilter(t *testing.T) {
+	issued := platform.DBRPMappingFilter{ClusterPattern: strPtr("^a")}
+	resumed := platform.DBRPMappingFilter{ClusterPattern: strPtr("^b")}
+
+	cur, err := platform.NewDBRPCursor(newTestMapping("clusterA", "db", "rp", false), issued)
+	if err != nil {
+		t.Fatalf("NewDBRPCursor: %v", err)
+	}
+
+	if _, _, _, err := cur.Decode(resumed); err != platform.ErrDBRPCursorFilterMismatch {
+		t.Fatalf("Decode with mismatched filter: got %v, want ErrDBRPCursorFilterMismatch", err)
+	}
+}
+
+func TestDBRPMappingFilterHashNoDelimiterCollision(t *testing.T) {
+	// These two filters must never hash identically: a delimiter-joined encoding of fields would
+	// collide here because both serialize to the same "|"-joined byte sequence.
+	a := platform.DBRPMappingFilter{ClusterPattern: strPtr("a|"), DatabasePattern: strPtr("b")}
+	b := platform.DBRPMappingFilter{ClusterPattern: strPtr("a"), DatabasePattern: strPtr("|b")}
+
+	if a.Hash() == b.Hash() {
+		t.Fatalf("distinct filters %+v and %+v hashed identically: %s", a, b, a.Hash())
+	}
+}
+
+func TestValidateDBRPBatchRejectsDuplicateDefault(t *testing.T) {
+	batch := []*platform.DBRPMapping{
+		newTestMapping("clusterA", "telegraf", "autogen", true),
+		newTestMapping("clusterA", "telegraf", "downsampled", true),
+	}
+
+	err := platform.ValidateDBRPBatch(batch)
+	if err == nil {
+		t.Fatal("expected an error for two mappings claiming Default for the same cluster/database")
+	}
+	batchErr, ok := err.(*platform.BatchError)
+	if !ok {
+		t.Fatalf("got error of type %T, want *platform.BatchError", err)
+	}
+	if len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 1 {
+		t.Fatalf("got failures %+v, want a single failure at index 1", batchErr.Failures)
+	}
+}
+
+func TestValidateDBRPBatchRejectsNilMapping(t *testing.T) {
+	batch := []*platform.DBRPMapping{
+		newTestMapping("clusterA", "telegraf", "autogen", false),
+		nil,
+	}
+
+	err := platform.ValidateDBRPBatch(batch)
+	if err == nil {
+		t.Fatal("expected an error for a nil mapping in the batch")
+	}
+	batchErr, ok := err.(*platform.BatchError)
+	if !ok {
+		t.Fatalf("got error of type %T, want *platform.BatchError", err)
+	}
+	if len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 1 {
+		t.Fatalf("got failures %+v, want a single failure at index 1", batchErr.Failures)
+	}
+}
+
+func TestValidateDBRPBatchAcceptsDistinctDefaults(t *testing.T) {
+	batch := []*platform.DBRPMapping{
+		newTestMapping("clusterA", "telegraf", "autogen", true),
+		newTestMapping("clusterA", "other", "autogen", true),
+	}
+
+	if err := platform.ValidateDBRPBatch(batch); err != nil {
+		t.Fatalf("unexpected error for defaults on distinct (cluster, database) pairs: %v", err)
+	}
+}
+
+func TestDBRPMappingFilterMatchesIncludeExclude(t *testing.T) {
+	filter := platform.DBRPMappingFilter{
+		DatabasePattern:               strPtr("^telegraf_"),
+		ExcludeRetentionPolicyPattern: strPtr("^_internal"),
+	}
+
+	included := newTestMapping("clusterA", "telegraf_host", "autogen", false)
+	if !filter.Matches(included) {
+		t.Fatalf("expected %+v to match %+v", filter, included)
+	}
+
+	wrongDatabase := newTestMapping("clusterA", "other_db", "autogen", false)
+	if filter.Matches(wrongDatabase) {
+		t.Fatalf("expected %+v not to match %+v", filter, wrongDatabase)
+	}
+
+	excludedRP := newTestMapping("clusterA", "telegraf_host", "_internal", false)
+	if filter.Matches(excludedRP) {
+		t.Fatalf("expected %+v not to match %+v (excluded RP)", filter, excludedRP)
+	}
+	// Confirm excludedRP is rejected because of the exclude pattern specifically, not because the
+	// include side of Matches rejects everything (which would make the assertion above pass vacuously).
+	withoutExclude := platform.DBRPMappingFilter{DatabasePattern: filter.DatabasePattern}
+	if !withoutExclude.Matches(excludedRP) {
+		t.Fatalf("expected %+v to match %+v once the exclude pattern is removed", withoutExclude, excludedRP)
+	}
+}